@@ -0,0 +1,167 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TerminationSignal is the signal the injected ephemeral container sends to
+// the target container once an essential container has been observed as
+// terminated.
+// +kubebuilder:validation:Enum=SIGTERM;SIGINT;SIGKILL
+type TerminationSignal string
+
+const (
+	SIGTERM TerminationSignal = "SIGTERM"
+	SIGINT  TerminationSignal = "SIGINT"
+	SIGKILL TerminationSignal = "SIGKILL"
+)
+
+// EssentialMode controls how multiple EssentialContainers combine to decide
+// whether a pod's essential workload has finished.
+type EssentialMode string
+
+const (
+	// ModeAnyOf injects as soon as any one listed essential container completes.
+	ModeAnyOf EssentialMode = "AnyOf"
+	// ModeAllOf injects only once every listed essential container has
+	// independently completed.
+	ModeAllOf EssentialMode = "AllOf"
+)
+
+// TerminationMode selects how the injected ephemeral container signals the
+// target container's PID 1.
+// +kubebuilder:validation:Enum=Auto;Ptrace;ProcessNamespace
+type TerminationMode string
+
+const (
+	// TerminationModeAuto picks ProcessNamespace when the pod has
+	// shareProcessNamespace: true, and Ptrace otherwise.
+	TerminationModeAuto TerminationMode = "Auto"
+	// TerminationModePtrace injects SYS_PTRACE and runs "kill <signal> 1"
+	// in the ephemeral container. Only reliably reaches the target when the
+	// pod shares its process namespace or the target's PID 1 is otherwise
+	// ptrace-visible.
+	TerminationModePtrace TerminationMode = "Ptrace"
+	// TerminationModeProcessNamespace requires shareProcessNamespace: true.
+	// It finds the target container's processes by matching /proc/*/cgroup
+	// against its container ID and signals every one of them directly,
+	// without SYS_PTRACE.
+	TerminationModeProcessNamespace TerminationMode = "ProcessNamespace"
+)
+
+// EssentialContainerPolicySpec defines which pods are governed by this policy,
+// which of their containers are essential, and how the ephemeral "kill"
+// sidecar should be injected once an essential container exits.
+type EssentialContainerPolicySpec struct {
+	// Selector narrows down the pods this policy applies to. NamespaceSelector
+	// is evaluated against the Namespace object of the pod; PodSelector is
+	// evaluated against the pod's own labels. Both are optional; an empty
+	// selector matches everything.
+	Selector PolicySelector `json:"selector,omitempty"`
+
+	// EssentialContainers lists the container name(s) that are considered
+	// essential for pods matched by this policy.
+	// +kubebuilder:validation:MinItems=1
+	EssentialContainers []string `json:"essentialContainers"`
+
+	// Mode controls how multiple EssentialContainers combine: "AnyOf" injects
+	// as soon as one of them completes, "AllOf" waits until every one of
+	// them has independently completed.
+	// +kubebuilder:validation:Enum=AnyOf;AllOf
+	// +kubebuilder:default=AnyOf
+	Mode EssentialMode `json:"mode,omitempty"`
+
+	// TerminationSignal is delivered to the target container's PID 1 by
+	// the injected ephemeral container.
+	// +kubebuilder:default=SIGINT
+	TerminationSignal TerminationSignal `json:"terminationSignal,omitempty"`
+
+	// TerminationMode selects how the ephemeral container reaches the target
+	// container's PID 1.
+	// +kubebuilder:default=Auto
+	TerminationMode TerminationMode `json:"terminationMode,omitempty"`
+
+	// TargetContainer is the container whose processes are signalled in
+	// TerminationModeProcessNamespace. Defaults to the pod's first container.
+	// TerminationModePtrace does not use it: it signals PID 1 as seen by the
+	// ephemeral container.
+	TargetContainer string `json:"targetContainer,omitempty"`
+
+	// Image is the image used for the injected ephemeral container.
+	// +kubebuilder:default="busybox"
+	Image string `json:"image,omitempty"`
+
+	// Command is the entrypoint used for the injected ephemeral container.
+	// When empty, the controller builds a default command for the resolved
+	// TerminationMode: "kill <signal> 1" for Ptrace, and a /proc walk that
+	// signals the target container's processes for ProcessNamespace.
+	Command []string `json:"command,omitempty"`
+
+	// SecurityContext is applied to the injected ephemeral container. When
+	// empty, the controller defaults to the SYS_PTRACE capability in
+	// TerminationModePtrace, and to no extra capabilities in
+	// TerminationModeProcessNamespace.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// PolicySelector selects the pods an EssentialContainerPolicy applies to.
+type PolicySelector struct {
+	// NamespaceSelector matches against Namespace labels.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// PodSelector matches against Pod labels.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// EssentialContainerPolicyStatus reports the observed state of the policy.
+type EssentialContainerPolicyStatus struct {
+	// ObservedGeneration is the most recent generation reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// MatchedPods is the number of pods currently matching this policy.
+	MatchedPods int `json:"matchedPods,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// EssentialContainerPolicy declares which container(s) in a set of pods are
+// essential, and how to react once one of them exits.
+type EssentialContainerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EssentialContainerPolicySpec   `json:"spec,omitempty"`
+	Status EssentialContainerPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EssentialContainerPolicyList contains a list of EssentialContainerPolicy.
+type EssentialContainerPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EssentialContainerPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EssentialContainerPolicy{}, &EssentialContainerPolicyList{})
+}
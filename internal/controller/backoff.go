@@ -0,0 +1,45 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "time"
+
+const (
+	// initialBackoff is the delay before the first retry of a failed injection.
+	initialBackoff = 1 * time.Second
+	// maxBackoff caps the exponential backoff between injection retries.
+	maxBackoff = 5 * time.Minute
+	// maxInjectionAttempts bounds how many times a single pod is retried
+	// before the retry budget is considered exhausted.
+	maxInjectionAttempts = 12
+)
+
+// backoffForAttempt returns the delay before retrying the attempt'th failed
+// injection (1-indexed), doubling from initialBackoff and capping at maxBackoff.
+func backoffForAttempt(attempt int) time.Duration {
+	if attempt <= 1 {
+		return initialBackoff
+	}
+	d := initialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
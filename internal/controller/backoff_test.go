@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffForAttempt(t *testing.T) {
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: initialBackoff},
+		{attempt: 1, want: initialBackoff},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 10, want: maxBackoff},
+		{attempt: 100, want: maxBackoff},
+	}
+
+	for _, tc := range cases {
+		if got := backoffForAttempt(tc.attempt); got != tc.want {
+			t.Errorf("backoffForAttempt(%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffForAttemptNeverExceedsMax(t *testing.T) {
+	for attempt := 1; attempt <= maxInjectionAttempts; attempt++ {
+		if got := backoffForAttempt(attempt); got > maxBackoff {
+			t.Errorf("backoffForAttempt(%d) = %v, exceeds maxBackoff %v", attempt, got, maxBackoff)
+		}
+	}
+}
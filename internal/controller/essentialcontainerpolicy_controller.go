@@ -0,0 +1,122 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pingcap/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+// EssentialContainerPolicyReconciler reconciles an EssentialContainerPolicy object.
+type EssentialContainerPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+//+kubebuilder:rbac:groups=essentialcontainers.sumanthkumarc.io,resources=essentialcontainerpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=essentialcontainers.sumanthkumarc.io,resources=essentialcontainerpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=essentialcontainers.sumanthkumarc.io,resources=essentialcontainerpolicies/finalizers,verbs=update
+
+// Reconcile keeps EssentialContainerPolicy.Status in sync with the pods it
+// currently matches. The PodReconciler is what actually acts on pods; this
+// reconciler only reports observed state back onto the policy object.
+func (r *EssentialContainerPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("essentialcontainerpolicy", req.NamespacedName)
+
+	policy := &essentialcontainersv1alpha1.EssentialContainerPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get EssentialContainerPolicy")
+		return ctrl.Result{}, err
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(policy.Namespace)); err != nil {
+		log.Error(err, "Failed to list pods in namespace")
+		return ctrl.Result{}, err
+	}
+
+	matched := 0
+	for i := range pods.Items {
+		ok, err := policySelectorMatches(ctx, r.Client, &policy.Spec.Selector, &pods.Items[i])
+		if err != nil {
+			log.Error(err, "Failed to evaluate selector", "pod", pods.Items[i].Name)
+			continue
+		}
+		if ok {
+			matched++
+		}
+	}
+
+	if policy.Status.MatchedPods != matched || policy.Status.ObservedGeneration != policy.Generation {
+		policy.Status.MatchedPods = matched
+		policy.Status.ObservedGeneration = policy.Generation
+		if err := r.Status().Update(ctx, policy); err != nil {
+			log.Error(err, "Failed to update EssentialContainerPolicy status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EssentialContainerPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&essentialcontainersv1alpha1.EssentialContainerPolicy{}).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPodToPolicies),
+		).
+		Complete(r)
+}
+
+// mapPodToPolicies maps a pod event to the EssentialContainerPolicy
+// object(s) in the same namespace, so that policy status reflects pod
+// churn without waiting for the next periodic resync.
+func (r *EssentialContainerPolicyReconciler) mapPodToPolicies(ctx context.Context, obj client.Object) []ctrl.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+
+	var policies essentialcontainersv1alpha1.EssentialContainerPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(pod.Namespace)); err != nil {
+		r.Log.Error(err, "Failed to list EssentialContainerPolicies for pod mapper")
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(policies.Items))
+	for i := range policies.Items {
+		requests = append(requests, ctrl.Request{
+			NamespacedName: client.ObjectKeyFromObject(&policies.Items[i]),
+		})
+	}
+	return requests
+}
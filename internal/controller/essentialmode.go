@@ -0,0 +1,131 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+// The predicate in PodReconciler.SetupWithManager evaluates each pod update
+// through a small state machine, one instance per (pod, policy) pair:
+//
+//	pending --(mode-specific quorum reached)--> satisfied
+//
+// "pending" is any state where isSatisfied returns false; "satisfied" is
+// reached the instant isSatisfied flips from false (on the old pod) to true
+// (on the new pod) - that edge, not the level, is what triggers injection,
+// so a pod that is already satisfied on every subsequent update does not
+// re-trigger. ModeAnyOf reaches satisfied the moment one listed essential
+// container completes; ModeAllOf only once every listed essential container
+// has independently completed. A future mode (e.g. "FirstOf", which cares
+// about which essential container completed first rather than just whether
+// all/any did) plugs in by adding a case below and a matching
+// EssentialMode constant in api/v1alpha1.
+
+// isSatisfied reports whether the pod's essential-container state, taken as
+// a whole, has reached the "exited" state for the given mode.
+func isSatisfied(pod *corev1.Pod, essentialContainers []string, mode essentialcontainersv1alpha1.EssentialMode) bool {
+	switch mode {
+	case essentialcontainersv1alpha1.ModeAllOf:
+		if len(essentialContainers) == 0 {
+			return false
+		}
+		for _, name := range essentialContainers {
+			if !containerCompleted(pod, name) {
+				return false
+			}
+		}
+		return true
+	case essentialcontainersv1alpha1.ModeAnyOf, "":
+		for _, name := range essentialContainers {
+			if containerCompleted(pod, name) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// containerCompleted reports whether the named container has transitioned
+// to Terminated with reason Completed.
+func containerCompleted(pod *corev1.Pod, name string) bool {
+	state := getContainerStatus(pod, name)
+	return getState(state) == "Terminated" && getStateReason(state) == "Completed"
+}
+
+// allEssentialContainersAllowed reports whether every container a policy
+// declares essential is permitted by the cluster-wide --essential-containers
+// allow-list. An empty allowed list means no restriction.
+//
+// This is a gate on whether the controller is allowed to act on the policy
+// at all, not an input to isSatisfied: narrowing EssentialContainers down to
+// just the allowed subset before checking satisfaction would silently weaken
+// ModeAllOf from "every declared container must complete" to "every allowed
+// container must complete", letting injection fire before a container the
+// policy actually requires has exited.
+func allEssentialContainersAllowed(wanted, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	allowedSet := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		allowedSet[name] = struct{}{}
+	}
+
+	for _, name := range wanted {
+		if _, ok := allowedSet[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// skipJobRestart reports whether the pod belongs to a Job configured to
+// restart failed containers in place (RestartPolicy: OnFailure) and the
+// essential container's most recent exit was non-zero. In that case the
+// kubelet is about to restart the container itself, so injecting a kill
+// sidecar would race the restart for no benefit.
+func skipJobRestart(pod *corev1.Pod, essentialContainers []string) bool {
+	if pod.Spec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		return false
+	}
+
+	ownedByJob := false
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "Job" && owner.APIVersion == batchv1.SchemeGroupVersion.String() {
+			ownedByJob = true
+			break
+		}
+	}
+	if !ownedByJob {
+		return false
+	}
+
+	for _, name := range essentialContainers {
+		state := getContainerStatus(pod, name)
+		if state.Terminated != nil && state.Terminated.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
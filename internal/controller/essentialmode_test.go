@@ -0,0 +1,126 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+// podWithContainerStates builds a minimal pod carrying one ContainerStatus
+// per name, completed marking which of them are Terminated/Completed.
+func podWithContainerStates(completed ...string) *corev1.Pod {
+	done := make(map[string]struct{}, len(completed))
+	for _, name := range completed {
+		done[name] = struct{}{}
+	}
+
+	pod := &corev1.Pod{}
+	for _, name := range []string{"a", "b", "c"} {
+		state := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		if _, ok := done[name]; ok {
+			state = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}}
+		}
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  name,
+			State: state,
+		})
+	}
+	return pod
+}
+
+func TestIsSatisfiedAnyOf(t *testing.T) {
+	pending := podWithContainerStates()
+	if isSatisfied(pending, []string{"a", "b"}, essentialcontainersv1alpha1.ModeAnyOf) {
+		t.Fatalf("expected pending pod to not be satisfied")
+	}
+
+	oneDone := podWithContainerStates("a")
+	if !isSatisfied(oneDone, []string{"a", "b"}, essentialcontainersv1alpha1.ModeAnyOf) {
+		t.Fatalf("expected AnyOf to be satisfied once one listed container completes")
+	}
+
+	// Default mode ("") behaves like AnyOf.
+	if !isSatisfied(oneDone, []string{"a", "b"}, "") {
+		t.Fatalf("expected empty mode to behave like AnyOf")
+	}
+}
+
+func TestIsSatisfiedAllOf(t *testing.T) {
+	oneDone := podWithContainerStates("a")
+	if isSatisfied(oneDone, []string{"a", "b"}, essentialcontainersv1alpha1.ModeAllOf) {
+		t.Fatalf("expected AllOf to stay pending until every listed container completes")
+	}
+
+	bothDone := podWithContainerStates("a", "b")
+	if !isSatisfied(bothDone, []string{"a", "b"}, essentialcontainersv1alpha1.ModeAllOf) {
+		t.Fatalf("expected AllOf to be satisfied once every listed container completes")
+	}
+}
+
+func TestIsSatisfiedAllOfIgnoresAllowListNarrowing(t *testing.T) {
+	// Regression test: AllOf must require every *declared* essential
+	// container to complete, even if a cluster-wide --essential-containers
+	// allow-list only permits a subset of them. Satisfaction is always
+	// computed against the full declared set.
+	oneDone := podWithContainerStates("a")
+	if isSatisfied(oneDone, []string{"a", "b"}, essentialcontainersv1alpha1.ModeAllOf) {
+		t.Fatalf("expected AllOf to require container %q too, regardless of any allow-list", "b")
+	}
+}
+
+func TestAllEssentialContainersAllowed(t *testing.T) {
+	if !allEssentialContainersAllowed([]string{"a", "b"}, nil) {
+		t.Fatalf("expected an empty allow-list to permit everything")
+	}
+	if !allEssentialContainersAllowed([]string{"a", "b"}, []string{"a", "b", "c"}) {
+		t.Fatalf("expected all wanted containers present in allow-list to be permitted")
+	}
+	if allEssentialContainersAllowed([]string{"a", "b"}, []string{"a"}) {
+		t.Fatalf("expected a policy declaring a non-allowed container to be rejected")
+	}
+}
+
+func TestSkipJobRestart(t *testing.T) {
+	pod := podWithContainerStates("a")
+	pod.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	pod.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: batchv1.SchemeGroupVersion.String(),
+		Kind:       "Job",
+	}}
+
+	if skipJobRestart(pod, []string{"a"}) {
+		t.Fatalf("expected no skip when the essential container exited with code 0")
+	}
+
+	pod.Status.ContainerStatuses[0].State.Terminated.ExitCode = 1
+	if !skipJobRestart(pod, []string{"a"}) {
+		t.Fatalf("expected skip when a Job-owned, RestartPolicy: OnFailure pod's essential container exits non-zero")
+	}
+
+	notOwnedByJob := podWithContainerStates("a")
+	notOwnedByJob.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	notOwnedByJob.Status.ContainerStatuses[0].State.Terminated.ExitCode = 1
+	if skipJobRestart(notOwnedByJob, []string{"a"}) {
+		t.Fatalf("expected no skip for a pod not owned by a Job")
+	}
+}
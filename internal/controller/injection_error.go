@@ -0,0 +1,34 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// InjectionError is returned by injectEphemeralContainer so Reconcile can
+// decide how to requeue. Retryable errors (e.g. a transient patch failure)
+// get exponential backoff up to the pod's retry budget; non-retryable
+// errors (e.g. the pod is already gone) are surfaced but never retried.
+type InjectionError struct {
+	Err       error
+	Retryable bool
+}
+
+func (e *InjectionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InjectionError) Unwrap() error {
+	return e.Err
+}
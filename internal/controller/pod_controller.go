@@ -23,14 +23,19 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pingcap/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+	"github.com/sumanthkumarc/kubernetes-essential-containers/internal/metrics"
 )
 
 // PodReconciler reconciles a Pod object
@@ -38,46 +43,139 @@ type PodReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Log    logr.Logger
+
+	// Cache remembers, per pod UID, which EssentialContainerPolicy matched
+	// at the time the essential-container-exited transition was observed.
+	Cache *PolicyCache
+
+	// Recorder emits Kubernetes Events onto the Pod object at each step of
+	// the injection flow, so `kubectl describe pod` and event-driven
+	// alerting pipelines can observe the controller without tailing logs.
+	Recorder record.EventRecorder
+
+	// RetryBudget bounds how many times injection is retried for a single
+	// pod before the controller gives up until a fresh essential-container
+	// termination is observed.
+	RetryBudget *RetryBudget
+
+	// AllowedEssentialContainers, when non-empty, restricts which container
+	// names any EssentialContainerPolicy is allowed to declare essential,
+	// as a cluster-wide guardrail set via the --essential-containers flag.
+	// An empty value allows any container name a policy declares.
+	AllowedEssentialContainers []string
 }
 
+const (
+	eventReasonEssentialContainerExited = "EssentialContainerExited"
+	eventReasonInjectAttempt            = "EphemeralContainerInjectAttempt"
+	eventReasonInjectSucceeded          = "EphemeralContainerInjectSucceeded"
+	eventReasonInjectFailed             = "EphemeralContainerInjectFailed"
+	eventReasonRetryBudgetExhausted     = "EphemeralContainerInjectRetryBudgetExhausted"
+	eventReasonProcessNamespaceFallback = "EphemeralContainerProcessNamespaceFallback"
+)
+
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete
 //+kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=core,resources=pods/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=pods/ephemeralcontainers,verbs=get;update;patch
+//+kubebuilder:rbac:groups=essentialcontainers.sumanthkumarc.io,resources=essentialcontainerpolicies,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.15.0/pkg/reconcile
+//
+// Reconcile's job ends once the ephemeral container has been patched in and
+// has signalled the target container's PID 1: whatever happens to the pod
+// afterwards (the kubelet restarting it, its owning Job/Deployment replacing
+// it, or it being deleted outright) is that owning controller's call, not
+// this one's. The RBAC on this reconciler still grants `delete` on pods -
+// left over from before the policy-driven design below existed - but there
+// is deliberately no pod-deletion step here: this controller only ever
+// observed what containers are "essential" to a user, with no way to infer
+// whether deleting the whole pod on their behalf is ever wanted, so it
+// leaves that decision to the pod's owner.
 func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("pod", req.NamespacedName)
-	fmt.Printf("\nEssential container exited, injecting process kill container in the pod %s in namespace %s\n", req.Name, req.Namespace)
 
 	pod := &corev1.Pod{}
 	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
 		if errors.IsNotFound(err) {
 			log.Info("Pod not found")
+			metrics.InjectionsTotal.WithLabelValues(metrics.ResultNotFound).Inc()
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get Pod")
 		return ctrl.Result{}, err
 	}
 
-	// Terminate the pid 1 in pod
-	// @todo add the pod deletion as events in namespace. Useful for debugging.
-	err := r.injectEphemeralContainer(ctx, pod, &log)
+	policy, ok := r.Cache.Get(pod.UID)
+	if !ok {
+		// The predicate matched this pod against a policy, but the cache
+		// entry is gone (e.g. controller restart). Re-resolve it rather
+		// than giving up.
+		var err error
+		policy, err = matchPolicy(ctx, r.Client, pod)
+		if err != nil {
+			log.Error(err, "Failed to re-resolve EssentialContainerPolicy for pod")
+			return ctrl.Result{}, err
+		}
+		if policy == nil {
+			log.Info("No EssentialContainerPolicy matches pod anymore, skipping")
+			return ctrl.Result{}, nil
+		}
+	}
 
-	if err != nil {
+	if r.RetryBudget.Exhausted(pod.UID) {
+		log.Info("Retry budget exhausted for pod, waiting for a new termination before retrying")
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Essential container exited, injecting process kill container", "policy", policy.Name)
+	r.Recorder.Eventf(pod, corev1.EventTypeNormal, eventReasonEssentialContainerExited,
+		"Essential container terminated (policy %q), injecting ephemeral kill container", policy.Name)
+
+	if err := r.injectEphemeralContainer(ctx, pod, policy, &log); err != nil {
 		log.Error(err, "Failed to inject ephemeral container into pod")
-		return ctrl.Result{}, err
+		r.Recorder.Eventf(pod, corev1.EventTypeWarning, eventReasonInjectFailed,
+			"Failed to inject ephemeral container: %v", err)
+		metrics.InjectionsTotal.WithLabelValues(metrics.ResultError).Inc()
+
+		if !err.Retryable {
+			return ctrl.Result{}, nil
+		}
+
+		attempts := r.RetryBudget.RecordFailure(pod.UID)
+		if attempts >= maxInjectionAttempts {
+			r.Recorder.Eventf(pod, corev1.EventTypeWarning, eventReasonRetryBudgetExhausted,
+				"Giving up after %d failed injection attempts; will retry on the next termination", attempts)
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{RequeueAfter: backoffForAttempt(attempts)}, nil
 	}
 
+	r.RetryBudget.Reset(pod.UID)
 	log.Info("Pod killed successfully")
+	r.Recorder.Event(pod, corev1.EventTypeNormal, eventReasonInjectSucceeded,
+		"Ephemeral container injected; target container's PID 1 will be signalled")
+	metrics.InjectionsTotal.WithLabelValues(metrics.ResultSuccess).Inc()
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Cache == nil {
+		r.Cache = NewPolicyCache()
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("essential-container-controller")
+	}
+	if r.RetryBudget == nil {
+		r.RetryBudget = NewRetryBudget(defaultRetryBudgetSize)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Pod{}).
 		// WORKAROUND - Since we can't easily get the old object in reconciler itself, easiest way for us
@@ -92,21 +190,49 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				oldPod := e.ObjectOld.(*corev1.Pod)
 				newPod := e.ObjectNew.(*corev1.Pod)
 
-				// Check if the container status has changed from Running to Terminated
-				// @todo get the container essetnial name dynamically
-				oldStatus := getState(getContainerStatus(oldPod, "main"))
+				policy, err := matchPolicy(context.Background(), r.Client, newPod)
+				if err != nil {
+					r.Log.Error(err, "Failed to match EssentialContainerPolicy for pod", "pod", newPod.Name)
+					return false
+				}
+				if policy == nil || len(policy.Spec.EssentialContainers) == 0 {
+					return false
+				}
+
+				essentialContainers := policy.Spec.EssentialContainers
+				if !allEssentialContainersAllowed(essentialContainers, r.AllowedEssentialContainers) {
+					r.Log.Info("EssentialContainerPolicy declares a container outside --essential-containers, skipping",
+						"policy", policy.Name, "pod", newPod.Name)
+					return false
+				}
 
-				newState := getContainerStatus(newPod, "main")
-				newStatus := getState(newState)
-				statusReason := getStateReason(newState)
+				if skipJobRestart(newPod, essentialContainers) {
+					return false
+				}
 
-				// @todo Check if given essenrtial container name is present in list of containers in pod
-				return (oldStatus == "Running" && newStatus == "Terminated") && statusReason == "Completed"
+				// See the essentialmode.go state-machine comment: only the
+				// pending->satisfied edge triggers injection.
+				wasSatisfied := isSatisfied(oldPod, essentialContainers, policy.Spec.Mode)
+				nowSatisfied := isSatisfied(newPod, essentialContainers, policy.Spec.Mode)
+
+				matched := !wasSatisfied && nowSatisfied
+				if matched {
+					r.Cache.Set(newPod.UID, policy)
+					// A fresh termination resets any exhausted retry budget
+					// from a previous round of failed injections.
+					r.RetryBudget.Reset(newPod.UID)
+					metrics.PredicateMatchesTotal.Inc()
+				}
+				return matched
 			},
 			CreateFunc: func(ce event.CreateEvent) bool {
+				metrics.PodsWatched.Inc()
 				return false
 			},
 			DeleteFunc: func(de event.DeleteEvent) bool {
+				r.Cache.Delete(de.Object.GetUID())
+				r.RetryBudget.Reset(de.Object.GetUID())
+				metrics.PodsWatched.Dec()
 				return false
 			},
 		}).
@@ -148,52 +274,94 @@ func getStateReason(state corev1.ContainerState) string {
 	}
 }
 
-// injectEphemeralContainer injects an ephemeral container into the running Pod and sets the entry point as "kill 1".
-func (r *PodReconciler) injectEphemeralContainer(ctx context.Context, pod *corev1.Pod, log *logr.Logger) error {
-	ec := &corev1.EphemeralContainer{
-		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
-			Name:      "essential-container-sidecar",
-			Image:     "busybox",
-			Command:   []string{"/bin/sh"},
-			Args:      []string{"-c", "kill -INT 1"},
-			TTY:       false,
-			Stdin:     false,
-			Resources: corev1.ResourceRequirements{},
-			SecurityContext: &corev1.SecurityContext{
-				Capabilities: &corev1.Capabilities{
-					Add: []corev1.Capability{
-						"SYS_PTRACE",
-					},
+// injectEphemeralContainer injects an ephemeral container into the running Pod, configured by policy
+// to send the policy's termination signal using the resolved TerminationMode, or to run the
+// policy's own Command when one is set.
+func (r *PodReconciler) injectEphemeralContainer(ctx context.Context, pod *corev1.Pod, policy *essentialcontainersv1alpha1.EssentialContainerPolicy, log *logr.Logger) *InjectionError {
+	image := policy.Spec.Image
+	if image == "" {
+		image = "busybox"
+	}
+
+	mode := resolveTerminationMode(pod, policy)
+
+	command := policy.Spec.Command
+	var args []string
+	if len(command) == 0 {
+		var full []string
+		switch mode {
+		case essentialcontainersv1alpha1.TerminationModeProcessNamespace:
+			target := targetContainerName(pod, policy)
+			containerID := getContainerID(pod, target)
+			if containerID == "" {
+				return &InjectionError{
+					Err:       fmt.Errorf("no ContainerID reported yet for target container %q", target),
+					Retryable: true,
+				}
+			}
+			full = processNamespaceKillCommand(containerID, policy.Spec.TerminationSignal)
+		default:
+			if pod.Spec.ShareProcessNamespace == nil || !*pod.Spec.ShareProcessNamespace {
+				r.Recorder.Event(pod, corev1.EventTypeWarning, eventReasonProcessNamespaceFallback,
+					"Pod does not share its process namespace; ptrace-based termination may be a no-op")
+			}
+			full = ptraceKillCommand(policy.Spec.TerminationSignal)
+		}
+		command, args = full[:2], full[2:]
+	}
+
+	securityContext := policy.Spec.SecurityContext
+	if securityContext == nil && mode != essentialcontainersv1alpha1.TerminationModeProcessNamespace {
+		securityContext = &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{
+					"SYS_PTRACE",
 				},
 			},
+		}
+	}
+
+	ec := &corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:            "essential-container-sidecar",
+			Image:           image,
+			Command:         command,
+			Args:            args,
+			TTY:             false,
+			Stdin:           false,
+			Resources:       corev1.ResourceRequirements{},
+			SecurityContext: securityContext,
 		},
 	}
 
+	r.Recorder.Eventf(pod, corev1.EventTypeNormal, eventReasonInjectAttempt,
+		"Patching pod with ephemeral container %q (image %q)", ec.Name, image)
+
 	podJS, _ := json.Marshal(pod)
 	copied := pod.DeepCopy()
 	copied.Spec.EphemeralContainers = append(copied.Spec.EphemeralContainers, *ec)
 
 	debugJS, err := json.Marshal(copied)
 	if err != nil {
-		return fmt.Errorf("error creating JSON for debug container: %v", err)
+		return &InjectionError{Err: fmt.Errorf("error creating JSON for debug container: %v", err)}
 	}
 
 	patch, err := strategicpatch.CreateTwoWayMergePatch(podJS, debugJS, pod)
 	if err != nil {
-		return fmt.Errorf("error creating patch to add debug container: %v", err)
+		return &InjectionError{Err: fmt.Errorf("error creating patch to add debug container: %v", err)}
 	}
 
-	// fmt.Printf("generated strategic merge patch for debug container: %s \n", patch)
-
 	src := r.SubResource("ephemeralcontainers")
+	timer := prometheus.NewTimer(metrics.InjectionDurationSeconds)
 	err = src.Patch(ctx, pod, client.RawPatch(types.StrategicMergePatchType, patch))
+	timer.ObserveDuration()
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return fmt.Errorf("pod not found: %s/%s", pod.Namespace, pod.Name)
+			return &InjectionError{Err: fmt.Errorf("pod not found: %s/%s", pod.Namespace, pod.Name)}
 		}
-		return err
+		return &InjectionError{Err: err, Retryable: true}
 	}
 
-	log.Info("Ephemeral container injected successfully")
+	log.Info("Ephemeral container injected successfully", "policy", policy.Name, "image", image)
 	return nil
 }
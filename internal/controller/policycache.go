@@ -0,0 +1,63 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+// PolicyCache remembers, for a given pod UID, which EssentialContainerPolicy
+// last matched it. The event predicate in SetupWithManager populates the
+// cache when it resolves a match so that Reconcile does not have to
+// re-evaluate selectors against the API server on every reconcile.
+type PolicyCache struct {
+	mu    sync.RWMutex
+	byPod map[types.UID]*essentialcontainersv1alpha1.EssentialContainerPolicy
+}
+
+// NewPolicyCache returns an empty, ready to use PolicyCache.
+func NewPolicyCache() *PolicyCache {
+	return &PolicyCache{
+		byPod: map[types.UID]*essentialcontainersv1alpha1.EssentialContainerPolicy{},
+	}
+}
+
+// Get returns the policy last matched for the given pod UID, if any.
+func (c *PolicyCache) Get(uid types.UID) (*essentialcontainersv1alpha1.EssentialContainerPolicy, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.byPod[uid]
+	return p, ok
+}
+
+// Set records the policy that matched the given pod UID.
+func (c *PolicyCache) Set(uid types.UID, policy *essentialcontainersv1alpha1.EssentialContainerPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byPod[uid] = policy
+}
+
+// Delete forgets the cached policy for the given pod UID.
+func (c *PolicyCache) Delete(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.byPod, uid)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+func TestPolicyCacheGetMissing(t *testing.T) {
+	c := NewPolicyCache()
+	if _, ok := c.Get(types.UID("pod-a")); ok {
+		t.Fatalf("Get() on empty cache = ok, want !ok")
+	}
+}
+
+func TestPolicyCacheSetAndGet(t *testing.T) {
+	c := NewPolicyCache()
+	uid := types.UID("pod-a")
+	policy := &essentialcontainersv1alpha1.EssentialContainerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+	}
+
+	c.Set(uid, policy)
+
+	got, ok := c.Get(uid)
+	if !ok {
+		t.Fatalf("Get() after Set() = !ok, want ok")
+	}
+	if got != policy {
+		t.Fatalf("Get() = %v, want the policy passed to Set()", got)
+	}
+}
+
+func TestPolicyCacheSetOverwrites(t *testing.T) {
+	c := NewPolicyCache()
+	uid := types.UID("pod-a")
+	first := &essentialcontainersv1alpha1.EssentialContainerPolicy{ObjectMeta: metav1.ObjectMeta{Name: "first"}}
+	second := &essentialcontainersv1alpha1.EssentialContainerPolicy{ObjectMeta: metav1.ObjectMeta{Name: "second"}}
+
+	c.Set(uid, first)
+	c.Set(uid, second)
+
+	got, ok := c.Get(uid)
+	if !ok || got.Name != "second" {
+		t.Fatalf("Get() = %v, ok=%v, want the most recently Set() policy", got, ok)
+	}
+}
+
+func TestPolicyCacheDelete(t *testing.T) {
+	c := NewPolicyCache()
+	uid := types.UID("pod-a")
+	c.Set(uid, &essentialcontainersv1alpha1.EssentialContainerPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a"}})
+
+	c.Delete(uid)
+
+	if _, ok := c.Get(uid); ok {
+		t.Fatalf("Get() after Delete() = ok, want !ok")
+	}
+
+	// Deleting an absent entry is a no-op, not an error.
+	c.Delete(types.UID("never-set"))
+}
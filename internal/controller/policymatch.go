@@ -0,0 +1,81 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+// matchPolicy returns the first EssentialContainerPolicy in the pod's
+// namespace whose selector matches the given pod, or nil if none do.
+// Policies are evaluated in list order, so ties are broken by the
+// apiserver's (effectively creation) ordering.
+func matchPolicy(ctx context.Context, c client.Client, pod *corev1.Pod) (*essentialcontainersv1alpha1.EssentialContainerPolicy, error) {
+	var policies essentialcontainersv1alpha1.EssentialContainerPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		matches, err := policySelectorMatches(ctx, c, &policy.Spec.Selector, pod)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return policy, nil
+		}
+	}
+	return nil, nil
+}
+
+// policySelectorMatches evaluates a PolicySelector against a pod.
+func policySelectorMatches(ctx context.Context, c client.Client, sel *essentialcontainersv1alpha1.PolicySelector, pod *corev1.Pod) (bool, error) {
+	if sel.PodSelector != nil {
+		podSel, err := metav1.LabelSelectorAsSelector(sel.PodSelector)
+		if err != nil {
+			return false, err
+		}
+		if !podSel.Matches(labels.Set(pod.Labels)) {
+			return false, nil
+		}
+	}
+
+	if sel.NamespaceSelector != nil {
+		nsSel, err := metav1.LabelSelectorAsSelector(sel.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		var ns corev1.Namespace
+		//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get
+		if err := c.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &ns); err != nil {
+			return false, err
+		}
+		if !nsSel.Matches(labels.Set(ns.Labels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
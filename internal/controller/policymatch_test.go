@@ -0,0 +1,169 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("clientgoscheme.AddToScheme() error = %v", err)
+	}
+	if err := essentialcontainersv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("essentialcontainersv1alpha1.AddToScheme() error = %v", err)
+	}
+	return scheme
+}
+
+func TestPolicySelectorMatches(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "team-a",
+			Labels:    map[string]string{"app": "worker"},
+		},
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{"env": "prod"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		sel  *essentialcontainersv1alpha1.PolicySelector
+		want bool
+	}{
+		{
+			name: "empty selector matches everything",
+			sel:  &essentialcontainersv1alpha1.PolicySelector{},
+			want: true,
+		},
+		{
+			name: "matching pod selector",
+			sel: &essentialcontainersv1alpha1.PolicySelector{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+			},
+			want: true,
+		},
+		{
+			name: "non-matching pod selector",
+			sel: &essentialcontainersv1alpha1.PolicySelector{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+			},
+			want: false,
+		},
+		{
+			name: "matching namespace selector",
+			sel: &essentialcontainersv1alpha1.PolicySelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+			want: true,
+		},
+		{
+			name: "non-matching namespace selector",
+			sel: &essentialcontainersv1alpha1.PolicySelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}},
+			},
+			want: false,
+		},
+		{
+			name: "pod selector matches but namespace selector does not",
+			sel: &essentialcontainersv1alpha1.PolicySelector{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(ns).Build()
+			got, err := policySelectorMatches(context.Background(), c, tt.sel, pod)
+			if err != nil {
+				t.Fatalf("policySelectorMatches() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("policySelectorMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPolicyTieBreaksByListOrder(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "team-a"},
+	}
+
+	first := &essentialcontainersv1alpha1.EssentialContainerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-first", Namespace: "team-a"},
+	}
+	second := &essentialcontainersv1alpha1.EssentialContainerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "b-second", Namespace: "team-a"},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(first, second).Build()
+
+	got, err := matchPolicy(context.Background(), c, pod)
+	if err != nil {
+		t.Fatalf("matchPolicy() error = %v", err)
+	}
+	if got == nil {
+		t.Fatalf("matchPolicy() = nil, want a match")
+	}
+	if got.Name != "a-first" {
+		t.Fatalf("matchPolicy() = %q, want the first policy in list order (%q)", got.Name, "a-first")
+	}
+}
+
+func TestMatchPolicyNoneMatch(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "team-a", Labels: map[string]string{"app": "worker"}},
+	}
+	policy := &essentialcontainersv1alpha1.EssentialContainerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"},
+		Spec: essentialcontainersv1alpha1.EssentialContainerPolicySpec{
+			Selector: essentialcontainersv1alpha1.PolicySelector{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(policy).Build()
+
+	got, err := matchPolicy(context.Background(), c, pod)
+	if err != nil {
+		t.Fatalf("matchPolicy() error = %v", err)
+	}
+	if got != nil {
+		t.Fatalf("matchPolicy() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"container/list"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultRetryBudgetSize bounds how many pods' retry state the controller
+// remembers at once, evicting the least recently touched entry once full so
+// a churny cluster doesn't grow this structure unbounded.
+const defaultRetryBudgetSize = 1024
+
+type retryBudgetEntry struct {
+	uid      types.UID
+	attempts int
+}
+
+// RetryBudget tracks, per pod UID, how many consecutive injection attempts
+// have failed since the last Running->Terminated transition was observed
+// for that pod. It is an in-memory LRU of bounded size.
+type RetryBudget struct {
+	mu       sync.Mutex
+	size     int
+	ll       *list.List
+	elements map[types.UID]*list.Element
+}
+
+// NewRetryBudget returns a RetryBudget holding state for at most size pods.
+// A size <= 0 uses defaultRetryBudgetSize.
+func NewRetryBudget(size int) *RetryBudget {
+	if size <= 0 {
+		size = defaultRetryBudgetSize
+	}
+	return &RetryBudget{
+		size:     size,
+		ll:       list.New(),
+		elements: map[types.UID]*list.Element{},
+	}
+}
+
+// Attempts returns the number of failed attempts recorded for uid.
+func (b *RetryBudget) Attempts(uid types.UID) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if el, ok := b.elements[uid]; ok {
+		return el.Value.(*retryBudgetEntry).attempts
+	}
+	return 0
+}
+
+// RecordFailure increments the failure count for uid and returns the new count.
+func (b *RetryBudget) RecordFailure(uid types.UID) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if el, ok := b.elements[uid]; ok {
+		entry := el.Value.(*retryBudgetEntry)
+		entry.attempts++
+		b.ll.MoveToFront(el)
+		return entry.attempts
+	}
+
+	entry := &retryBudgetEntry{uid: uid, attempts: 1}
+	el := b.ll.PushFront(entry)
+	b.elements[uid] = el
+
+	if b.ll.Len() > b.size {
+		oldest := b.ll.Back()
+		if oldest != nil {
+			b.ll.Remove(oldest)
+			delete(b.elements, oldest.Value.(*retryBudgetEntry).uid)
+		}
+	}
+	return entry.attempts
+}
+
+// Reset forgets any recorded failures for uid, e.g. once a new
+// Running->Terminated transition is observed for the pod.
+func (b *RetryBudget) Reset(uid types.UID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if el, ok := b.elements[uid]; ok {
+		b.ll.Remove(el)
+		delete(b.elements, uid)
+	}
+}
+
+// Exhausted reports whether uid has used up its retry budget.
+func (b *RetryBudget) Exhausted(uid types.UID) bool {
+	return b.Attempts(uid) >= maxInjectionAttempts
+}
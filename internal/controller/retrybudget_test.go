@@ -0,0 +1,93 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRetryBudgetRecordFailureIncrements(t *testing.T) {
+	b := NewRetryBudget(0)
+	uid := types.UID("pod-a")
+
+	for i := 1; i <= 3; i++ {
+		if got := b.RecordFailure(uid); got != i {
+			t.Fatalf("RecordFailure() attempt %d = %d, want %d", i, got, i)
+		}
+	}
+
+	if got := b.Attempts(uid); got != 3 {
+		t.Fatalf("Attempts() = %d, want 3", got)
+	}
+}
+
+func TestRetryBudgetExhaustion(t *testing.T) {
+	b := NewRetryBudget(0)
+	uid := types.UID("pod-a")
+
+	for i := 0; i < maxInjectionAttempts-1; i++ {
+		b.RecordFailure(uid)
+		if b.Exhausted(uid) {
+			t.Fatalf("budget exhausted too early at attempt %d", i+1)
+		}
+	}
+
+	b.RecordFailure(uid)
+	if !b.Exhausted(uid) {
+		t.Fatalf("expected budget to be exhausted after %d attempts", maxInjectionAttempts)
+	}
+}
+
+func TestRetryBudgetReset(t *testing.T) {
+	b := NewRetryBudget(0)
+	uid := types.UID("pod-a")
+
+	for i := 0; i < maxInjectionAttempts; i++ {
+		b.RecordFailure(uid)
+	}
+	if !b.Exhausted(uid) {
+		t.Fatalf("expected budget to be exhausted")
+	}
+
+	b.Reset(uid)
+	if b.Exhausted(uid) {
+		t.Fatalf("expected budget to no longer be exhausted after Reset")
+	}
+	if got := b.Attempts(uid); got != 0 {
+		t.Fatalf("Attempts() after Reset = %d, want 0", got)
+	}
+}
+
+func TestRetryBudgetEvictsLeastRecentlyUsed(t *testing.T) {
+	b := NewRetryBudget(2)
+
+	b.RecordFailure(types.UID("pod-a"))
+	b.RecordFailure(types.UID("pod-b"))
+	b.RecordFailure(types.UID("pod-c"))
+
+	if got := b.Attempts(types.UID("pod-a")); got != 0 {
+		t.Fatalf("expected pod-a to be evicted, got attempts=%d", got)
+	}
+	if got := b.Attempts(types.UID("pod-b")); got != 1 {
+		t.Fatalf("expected pod-b to remain tracked, got attempts=%d", got)
+	}
+	if got := b.Attempts(types.UID("pod-c")); got != 1 {
+		t.Fatalf("expected pod-c to remain tracked, got attempts=%d", got)
+	}
+}
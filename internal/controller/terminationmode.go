@@ -0,0 +1,132 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+// signalFlags maps a TerminationSignal to the flag `kill` expects.
+var signalFlags = map[essentialcontainersv1alpha1.TerminationSignal]string{
+	essentialcontainersv1alpha1.SIGTERM: "-TERM",
+	essentialcontainersv1alpha1.SIGINT:  "-INT",
+	essentialcontainersv1alpha1.SIGKILL: "-KILL",
+}
+
+func signalFlag(signal essentialcontainersv1alpha1.TerminationSignal) string {
+	if signal == "" {
+		signal = essentialcontainersv1alpha1.SIGINT
+	}
+	return signalFlags[signal]
+}
+
+// targetContainerName resolves which container the ephemeral sidecar should
+// signal: the policy's explicit TargetContainer, or the pod's first
+// container when unset.
+func targetContainerName(pod *corev1.Pod, policy *essentialcontainersv1alpha1.EssentialContainerPolicy) string {
+	if policy.Spec.TargetContainer != "" {
+		return policy.Spec.TargetContainer
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// resolveTerminationMode applies TerminationModeAuto: ProcessNamespace when
+// the pod shares its process namespace, Ptrace otherwise.
+func resolveTerminationMode(pod *corev1.Pod, policy *essentialcontainersv1alpha1.EssentialContainerPolicy) essentialcontainersv1alpha1.TerminationMode {
+	mode := policy.Spec.TerminationMode
+	if mode != "" && mode != essentialcontainersv1alpha1.TerminationModeAuto {
+		return mode
+	}
+	if pod.Spec.ShareProcessNamespace != nil && *pod.Spec.ShareProcessNamespace {
+		return essentialcontainersv1alpha1.TerminationModeProcessNamespace
+	}
+	return essentialcontainersv1alpha1.TerminationModePtrace
+}
+
+// ptraceKillCommand builds the default ephemeral container entrypoint for
+// TerminationModePtrace: send the signal to PID 1 as seen by the ephemeral
+// container itself, which only lines up with the target container when the
+// pod's process namespace happens to be shared.
+func ptraceKillCommand(signal essentialcontainersv1alpha1.TerminationSignal) []string {
+	return []string{"/bin/sh", "-c", fmt.Sprintf("kill %s 1", signalFlag(signal))}
+}
+
+// processNamespaceKillCommand builds the ephemeral container entrypoint for
+// TerminationModeProcessNamespace: walk /proc, match each process's cgroup
+// against the target container's ID, and signal every matching PID (as seen
+// in the pod's shared process namespace) directly. This requires shareProcessNamespace: true so the ephemeral
+// container's /proc reflects every container in the pod, but needs no
+// SYS_PTRACE capability since it is signalling a process sharing its own
+// namespace rather than reaching across namespaces.
+func processNamespaceKillCommand(containerID string, signal essentialcontainersv1alpha1.TerminationSignal) []string {
+	cid := shortContainerID(containerID)
+	if cid == "" {
+		// An empty pattern would make the grep below match every process's
+		// cgroup file, signalling whichever process /proc happens to list
+		// first instead of the intended target. Fail loudly instead.
+		return []string{"/bin/sh", "-c", `echo "essential-container-sidecar: target container has no ContainerID yet" >&2; exit 1`}
+	}
+	// `ls /proc | sort -n` walks PIDs in numeric order so the real entrypoint
+	// (typically the lowest PID in the container's cgroup) is signalled
+	// first - a plain glob sorts lexicographically, where e.g. PID 9
+	// sorts after PID 21. Every matching PID is signalled, not just the
+	// first one found, since a container's forked children share its cgroup
+	// too and a lone survivor would otherwise keep the pod alive.
+	script := fmt.Sprintf(`pids=""
+for p in $(ls /proc | grep -E '^[0-9]+$' | sort -n); do
+  if grep -q %q "/proc/$p/cgroup" 2>/dev/null; then
+    pids="$pids $p"
+  fi
+done
+if [ -z "$pids" ]; then
+  echo "essential-container-sidecar: no process found for container %s" >&2
+  exit 1
+fi
+for p in $pids; do
+  kill %s "$p"
+done`, cid, cid, signalFlag(signal))
+	return []string{"/bin/sh", "-c", script}
+}
+
+// shortContainerID strips the CRI runtime prefix (e.g. "containerd://") a
+// ContainerStatus.ContainerID carries, leaving the bare ID that shows up in
+// /proc/*/cgroup.
+func shortContainerID(containerID string) string {
+	if idx := strings.LastIndex(containerID, "://"); idx != -1 {
+		return containerID[idx+3:]
+	}
+	return containerID
+}
+
+// getContainerID returns the runtime container ID for the named container,
+// or "" if the pod has no status for it yet.
+func getContainerID(pod *corev1.Pod, containerName string) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.ContainerID
+		}
+	}
+	return ""
+}
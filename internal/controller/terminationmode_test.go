@@ -0,0 +1,119 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	essentialcontainersv1alpha1 "github.com/sumanthkumarc/kubernetes-essential-containers/api/v1alpha1"
+)
+
+func TestShortContainerID(t *testing.T) {
+	tests := []struct {
+		name        string
+		containerID string
+		want        string
+	}{
+		{name: "empty", containerID: "", want: ""},
+		{name: "containerd prefix", containerID: "containerd://abc123", want: "abc123"},
+		{name: "docker prefix", containerID: "docker://def456", want: "def456"},
+		{name: "no prefix", containerID: "bare-id", want: "bare-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shortContainerID(tt.containerID); got != tt.want {
+				t.Fatalf("shortContainerID(%q) = %q, want %q", tt.containerID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTerminationMode(t *testing.T) {
+	shared := true
+	notShared := false
+
+	tests := []struct {
+		name   string
+		pod    *corev1.Pod
+		policy *essentialcontainersv1alpha1.EssentialContainerPolicy
+		want   essentialcontainersv1alpha1.TerminationMode
+	}{
+		{
+			name:   "auto with shared process namespace resolves to ProcessNamespace",
+			pod:    &corev1.Pod{Spec: corev1.PodSpec{ShareProcessNamespace: &shared}},
+			policy: &essentialcontainersv1alpha1.EssentialContainerPolicy{},
+			want:   essentialcontainersv1alpha1.TerminationModeProcessNamespace,
+		},
+		{
+			name:   "auto without shared process namespace resolves to Ptrace",
+			pod:    &corev1.Pod{Spec: corev1.PodSpec{ShareProcessNamespace: &notShared}},
+			policy: &essentialcontainersv1alpha1.EssentialContainerPolicy{},
+			want:   essentialcontainersv1alpha1.TerminationModePtrace,
+		},
+		{
+			name: "explicit mode overrides auto-detection",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{ShareProcessNamespace: &shared}},
+			policy: &essentialcontainersv1alpha1.EssentialContainerPolicy{
+				Spec: essentialcontainersv1alpha1.EssentialContainerPolicySpec{
+					TerminationMode: essentialcontainersv1alpha1.TerminationModePtrace,
+				},
+			},
+			want: essentialcontainersv1alpha1.TerminationModePtrace,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveTerminationMode(tt.pod, tt.policy); got != tt.want {
+				t.Fatalf("resolveTerminationMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessNamespaceKillCommandContainsSignalFlag(t *testing.T) {
+	full := processNamespaceKillCommand("containerd://abc123", essentialcontainersv1alpha1.SIGKILL)
+	script := strings.Join(full, "\n")
+
+	if !strings.Contains(script, "-KILL") {
+		t.Fatalf("generated script does not contain expected signal flag -KILL: %s", script)
+	}
+	if !strings.Contains(script, "abc123") {
+		t.Fatalf("generated script does not reference the short container ID: %s", script)
+	}
+	if !strings.Contains(script, "sort -n") {
+		t.Fatalf("generated script does not sort PIDs numerically: %s", script)
+	}
+}
+
+func TestProcessNamespaceKillCommandEmptyContainerID(t *testing.T) {
+	full := processNamespaceKillCommand("", essentialcontainersv1alpha1.SIGTERM)
+	script := strings.Join(full, "\n")
+
+	if !strings.Contains(script, "exit 1") {
+		t.Fatalf("expected an empty containerID to produce a failing script, got: %s", script)
+	}
+	// Regression guard: an empty cgroup match pattern would make `grep -q ""`
+	// match every process's cgroup file instead of none.
+	if strings.Contains(script, `grep -q "" `) {
+		t.Fatalf("generated script greps for an empty pattern, which matches every process: %s", script)
+	}
+}
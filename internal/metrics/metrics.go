@@ -0,0 +1,71 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics registers the Prometheus metrics emitted by the
+// essential-container controller onto controller-runtime's metrics
+// registry, which the manager already scrapes at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Injection result labels for InjectionsTotal.
+const (
+	ResultSuccess  = "success"
+	ResultError    = "error"
+	ResultNotFound = "notfound"
+)
+
+var (
+	// InjectionsTotal counts ephemeral-container injection attempts by outcome.
+	InjectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "essential_container_injections_total",
+		Help: "Total number of ephemeral container injection attempts, by result.",
+	}, []string{"result"})
+
+	// InjectionDurationSeconds observes how long the ephemeralcontainers
+	// subresource patch takes to complete.
+	InjectionDurationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "essential_container_injection_duration_seconds",
+		Help:    "Time taken to patch a pod with the ephemeral kill container.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// PodsWatched tracks the number of pods currently being observed by the
+	// essential-container predicate.
+	PodsWatched = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "essential_container_pods_watched",
+		Help: "Number of pods currently watched by the essential-container controller.",
+	})
+
+	// PredicateMatchesTotal counts how many times the essential-container
+	// predicate has matched a pod update and requested a reconcile.
+	PredicateMatchesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "essential_container_predicate_matches_total",
+		Help: "Total number of pod updates that matched the essential-container predicate.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		InjectionsTotal,
+		InjectionDurationSeconds,
+		PodsWatched,
+		PredicateMatchesTotal,
+	)
+}